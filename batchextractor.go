@@ -0,0 +1,211 @@
+package gotldextract
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+)
+
+// maxTrieLabels bounds how many labels of a domain BatchExtractor will
+// walk into its trie. A DNS name is at most 253 bytes, which even at one
+// byte per label allows no more than about 127 labels, so this is a safe
+// upper bound rather than a real-world limit.
+const maxTrieLabels = 128
+
+// trieNode is one label of a reverse-label trie built from a public
+// suffix list: children are keyed by label and walked starting from the
+// TLD inward, e.g. the rule "co.uk" lives at root.children["uk"].children["co"].
+type trieNode struct {
+	children map[string]*trieNode
+	rule     pslRule
+	hasRule  bool
+}
+
+// BatchExtractor is an alternative to Extractor for high-volume workloads,
+// such as extracting the output of a subdomain enumeration tool in a tight
+// loop, where Extract's per-call allocations (label slices, joined
+// substrings) dominate CPU. It builds the public suffix list into a trie
+// once up front, and ExtractInto reuses a pooled label buffer and slices
+// directly into the input string instead.
+//
+// BatchExtractor does not perform IDNA normalization, strict FQDN
+// validation, or runtime suffix list reloading; use Extractor for those.
+// It is safe for concurrent use.
+type BatchExtractor struct {
+	root      *trieNode
+	labelPool sync.Pool
+}
+
+// NewBatchExtractor builds a BatchExtractor from the public suffix list
+// bundled with gotldextract.
+func NewBatchExtractor() *BatchExtractor {
+	return newBatchExtractor(defaultSnapshot())
+}
+
+// NewBatchExtractorFromReader builds a BatchExtractor from a public suffix
+// list read from r, instead of the bundled default.
+func NewBatchExtractorFromReader(r io.Reader) (*BatchExtractor, error) {
+	snap, err := parseSuffixList(r)
+	if err != nil {
+		return nil, fmt.Errorf("gotldextract: parsing suffix list: %w", err)
+	}
+	return newBatchExtractor(snap), nil
+}
+
+func newBatchExtractor(snap *suffixListSnapshot) *BatchExtractor {
+	root := &trieNode{children: make(map[string]*trieNode)}
+	for text, rule := range snap.rules {
+		node := root
+		labels := strings.Split(text, ".")
+		for i := len(labels) - 1; i >= 0; i-- {
+			label := labels[i]
+			child, ok := node.children[label]
+			if !ok {
+				child = &trieNode{children: make(map[string]*trieNode)}
+				node.children[label] = child
+			}
+			node = child
+		}
+		node.rule, node.hasRule = rule, true
+	}
+
+	be := &BatchExtractor{root: root}
+	be.labelPool.New = func() any {
+		buf := make([]int, 0, maxTrieLabels)
+		return &buf
+	}
+	return be
+}
+
+// ExtractInto extracts name's subdomain/domain/TLD parts into dst,
+// overwriting its previous contents. It performs no IDNA normalization or
+// FQDN validation, and, unlike Extract, allocates nothing on its hot path:
+// Subdomain/Domain/TLD are slices of name itself, and the scratch label
+// buffer used to walk the trie comes from a sync.Pool.
+func (be *BatchExtractor) ExtractInto(dst *Result, name string) error {
+	name = cleanDomain(name)
+	if name == "" {
+		return fmt.Errorf("gotldextract: empty domain")
+	}
+
+	offsetsPtr := be.labelPool.Get().(*[]int)
+	offsets := labelOffsets((*offsetsPtr)[:0], name)
+	*offsetsPtr = offsets
+	defer be.labelPool.Put(offsetsPtr)
+
+	numLabels := len(offsets) - 1
+	suffixLabels, _ := be.publicSuffixLabels(name, offsets, numLabels, SuffixICANNAndPrivate)
+
+	splitAt := numLabels - suffixLabels
+	if splitAt < 0 {
+		splitAt = 0
+	}
+
+	dst.TLD = name[offsets[splitAt]:len(name)]
+	if splitAt == 0 {
+		dst.Domain = ""
+		dst.Subdomain = ""
+	} else {
+		dst.Domain = name[offsets[splitAt-1] : offsets[splitAt]-1]
+		if splitAt == 1 {
+			dst.Subdomain = ""
+		} else {
+			dst.Subdomain = name[:offsets[splitAt-1]-1]
+		}
+	}
+	// Unicode()/ASCII() convert lazily from rawFQDN on first call, so no
+	// IDNA conversion (or allocation) happens here; clear any forms cached
+	// from dst's previous contents.
+	dst.rawFQDN = name
+	dst.transitional = false
+	dst.unicodeFQDN = ""
+	dst.unicodeComputed = false
+	dst.asciiFQDN = ""
+	dst.asciiComputed = false
+
+	return nil
+}
+
+// ExtractMany extracts every name in names into the corresponding slot of
+// out, which must have the same length as names. It exists as a
+// convenience for bulk callers; each name still goes through ExtractInto,
+// so a name that fails to extract leaves its slot as it was, and every
+// other slot is unaffected. ExtractMany itself never stops early on a per-item error: it
+// processes the whole batch and returns the per-item errors joined
+// together (via errors.Join), or nil if every name extracted cleanly. Use
+// errors.Is/As or unwrap with errors.Join's Unwrap() []error to inspect
+// individual failures.
+func (be *BatchExtractor) ExtractMany(names []string, out []Result) error {
+	if len(names) != len(out) {
+		return fmt.Errorf("gotldextract: ExtractMany: len(names)=%d != len(out)=%d", len(names), len(out))
+	}
+	var errs []error
+	for i, name := range names {
+		if err := be.ExtractInto(&out[i], name); err != nil {
+			errs = append(errs, fmt.Errorf("gotldextract: extracting %q: %w", name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// labelOffsets scans name for '.' separators without allocating a []string,
+// appending each label's start offset to (and returning) buf, followed by
+// a final sentinel of len(name)+1. With that sentinel, every label,
+// including the last, spans name[buf[i]:buf[i+1]-1].
+func labelOffsets(buf []int, name string) []int {
+	buf = append(buf, 0)
+	for i := 0; i < len(name); i++ {
+		if name[i] == '.' {
+			buf = append(buf, i+1)
+		}
+	}
+	return append(buf, len(name)+1)
+}
+
+// publicSuffixLabels walks be's trie along name's labels from the TLD
+// inward, then, mirroring suffixListSnapshot.lookup, considers the
+// resulting path from its deepest (most specific) node back to its
+// shallowest, returning the label count of the first rule that matches
+// set, adjusted for wildcard/exception semantics. If no rule matches, it
+// falls back to the implicit "*" rule: the last label alone.
+func (be *BatchExtractor) publicSuffixLabels(name string, offsets []int, numLabels int, set SuffixSet) (n int, icann bool) {
+	var path [maxTrieLabels]*trieNode
+	depth := 0
+
+	node := be.root
+	for d := 1; d <= numLabels && depth < maxTrieLabels; d++ {
+		k := numLabels - d
+		label := name[offsets[k] : offsets[k+1]-1]
+		child, ok := node.children[label]
+		if !ok {
+			break
+		}
+		node = child
+		path[depth] = node
+		depth++
+	}
+
+	for d := depth; d >= 1; d-- {
+		candidate := path[d-1]
+		if !candidate.hasRule || !set.allows(candidate.rule.private) {
+			continue
+		}
+
+		switch candidate.rule.kind {
+		case ruleWildcard:
+			if d == numLabels {
+				// The wildcard needs one more label to its left.
+				continue
+			}
+			return d + 1, !candidate.rule.private
+		case ruleException:
+			return d - 1, !candidate.rule.private
+		default:
+			return d, !candidate.rule.private
+		}
+	}
+
+	return 1, false
+}
@@ -0,0 +1,166 @@
+package gotldextract
+
+import "testing"
+
+func TestBatchExtractorExtractInto(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		subdomain string
+		domain    string
+		tld       string
+	}{
+		{"simple domain", "example.com", "", "example", "com"},
+		{"subdomain", "www.example.com", "www", "example", "com"},
+		{"multiple subdomains", "a.b.c.example.com", "a.b.c", "example", "com"},
+		{"UK domain", "example.co.uk", "", "example", "co.uk"},
+		{"UK domain with subdomain", "www.example.co.uk", "www", "example", "co.uk"},
+		{"exception rule", "www.city.kawasaki.jp", "www", "city", "kawasaki.jp"},
+		{"wildcard rule", "foo.kawasaki.jp", "", "", "foo.kawasaki.jp"},
+		{"just TLD", "com", "", "", "com"},
+	}
+
+	be := NewBatchExtractor()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got Result
+			if err := be.ExtractInto(&got, tt.input); err != nil {
+				t.Fatalf("ExtractInto(%q): %v", tt.input, err)
+			}
+			if got.Subdomain != tt.subdomain || got.Domain != tt.domain || got.TLD != tt.tld {
+				t.Errorf("ExtractInto(%q) = {%q, %q, %q}, want {%q, %q, %q}",
+					tt.input, got.Subdomain, got.Domain, got.TLD,
+					tt.subdomain, tt.domain, tt.tld)
+			}
+		})
+	}
+}
+
+func TestBatchExtractorMatchesExtract(t *testing.T) {
+	be := NewBatchExtractor()
+
+	inputs := []string{
+		"example.com", "www.example.com", "a.b.c.example.com",
+		"example.co.uk", "www.example.co.uk",
+		"www.city.kawasaki.jp", "foo.kawasaki.jp", "city.kawasaki.jp",
+		"example.github.io", "com",
+	}
+
+	for _, input := range inputs {
+		want, err := Extract(input)
+		if err != nil {
+			t.Fatalf("Extract(%q): %v", input, err)
+		}
+
+		var got Result
+		if err := be.ExtractInto(&got, input); err != nil {
+			t.Fatalf("ExtractInto(%q): %v", input, err)
+		}
+
+		if got.Subdomain != want.Subdomain || got.Domain != want.Domain || got.TLD != want.TLD {
+			t.Errorf("ExtractInto(%q) = {%q, %q, %q}, want {%q, %q, %q} (from Extract)",
+				input, got.Subdomain, got.Domain, got.TLD,
+				want.Subdomain, want.Domain, want.TLD)
+		}
+	}
+}
+
+func TestBatchExtractorExtractIntoUnicodeASCII(t *testing.T) {
+	be := NewBatchExtractor()
+
+	var got Result
+	if err := be.ExtractInto(&got, "www.example.com"); err != nil {
+		t.Fatalf("ExtractInto: %v", err)
+	}
+
+	if got.Unicode() != "www.example.com" {
+		t.Errorf("Unicode() = %q, want %q", got.Unicode(), "www.example.com")
+	}
+	if got.ASCII() != "www.example.com" {
+		t.Errorf("ASCII() = %q, want %q", got.ASCII(), "www.example.com")
+	}
+}
+
+func TestBatchExtractorExtractMany(t *testing.T) {
+	be := NewBatchExtractor()
+
+	names := []string{"example.com", "www.example.co.uk", "foo.kawasaki.jp"}
+	out := make([]Result, len(names))
+
+	if err := be.ExtractMany(names, out); err != nil {
+		t.Fatalf("ExtractMany: %v", err)
+	}
+
+	if out[0].String() != "example.com" {
+		t.Errorf("out[0] = %q, want %q", out[0].String(), "example.com")
+	}
+	if out[1].String() != "example.co.uk" {
+		t.Errorf("out[1] = %q, want %q", out[1].String(), "example.co.uk")
+	}
+	if out[2].String() != "foo.kawasaki.jp" {
+		t.Errorf("out[2] = %q, want %q", out[2].String(), "foo.kawasaki.jp")
+	}
+}
+
+func TestBatchExtractorExtractManyLengthMismatch(t *testing.T) {
+	be := NewBatchExtractor()
+
+	err := be.ExtractMany([]string{"example.com"}, make([]Result, 2))
+	if err == nil {
+		t.Fatal("expected an error for mismatched slice lengths, got nil")
+	}
+}
+
+func TestBatchExtractorExtractManyContinuesPastErrors(t *testing.T) {
+	be := NewBatchExtractor()
+
+	names := []string{"example.com", "", "www.example.co.uk"}
+	out := make([]Result, len(names))
+
+	err := be.ExtractMany(names, out)
+	if err == nil {
+		t.Fatal("expected an error for the empty name, got nil")
+	}
+
+	if out[0].String() != "example.com" {
+		t.Errorf("out[0] = %q, want %q", out[0].String(), "example.com")
+	}
+	if out[2].String() != "example.co.uk" {
+		t.Errorf("out[2] = %q, want %q", out[2].String(), "example.co.uk")
+	}
+}
+
+func TestBatchExtractorExtractIntoAllocs(t *testing.T) {
+	be := NewBatchExtractor()
+	var dst Result
+
+	allocs := testing.AllocsPerRun(1000, func() {
+		if err := be.ExtractInto(&dst, "a.b.www.example.co.uk"); err != nil {
+			t.Fatal(err)
+		}
+	})
+	if allocs > 0 {
+		t.Errorf("ExtractInto allocated %.0f times per call, want 0", allocs)
+	}
+}
+
+func BenchmarkExtract(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		if _, err := Extract("a.b.www.example.co.uk"); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkBatchExtractorExtractInto(b *testing.B) {
+	be := NewBatchExtractor()
+	var dst Result
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := be.ExtractInto(&dst, "a.b.www.example.co.uk"); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
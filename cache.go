@@ -0,0 +1,82 @@
+package gotldextract
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+const (
+	cacheFileName = "public_suffix_list.dat"
+	etagFileName  = "public_suffix_list.dat.etag"
+)
+
+var httpClient = &http.Client{Timeout: 30 * time.Second}
+
+// cacheDir returns the directory gotldextract caches a fetched public
+// suffix list in, honoring $XDG_CACHE_HOME.
+func cacheDir() (string, error) {
+	base := os.Getenv("XDG_CACHE_HOME")
+	if base == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", err
+		}
+		base = filepath.Join(home, ".cache")
+	}
+	return filepath.Join(base, "gotldextract"), nil
+}
+
+// fetchSuffixList retrieves the public suffix list at url, using the
+// on-disk cache directory (keyed by ETag, via If-None-Match) to avoid
+// re-downloading a list that hasn't changed.
+func fetchSuffixList(url string) ([]byte, error) {
+	dir, err := cacheDir()
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+
+	cachePath := filepath.Join(dir, cacheFileName)
+	etagPath := filepath.Join(dir, etagFileName)
+
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	if etag, err := os.ReadFile(etagPath); err == nil {
+		req.Header.Set("If-None-Match", string(etag))
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusNotModified:
+		return os.ReadFile(cachePath)
+
+	case http.StatusOK:
+		data, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return nil, err
+		}
+		if err := os.WriteFile(cachePath, data, 0o644); err != nil {
+			return nil, err
+		}
+		if etag := resp.Header.Get("ETag"); etag != "" {
+			_ = os.WriteFile(etagPath, []byte(etag), 0o644)
+		}
+		return data, nil
+
+	default:
+		return nil, fmt.Errorf("unexpected status fetching %s: %s", url, resp.Status)
+	}
+}
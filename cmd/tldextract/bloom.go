@@ -0,0 +1,56 @@
+package main
+
+import "hash/fnv"
+
+// boundedBloomFilter is a fixed-size, therefore memory-bounded, Bloom
+// filter used to approximately deduplicate a high-volume stream of keys
+// without holding every distinct key seen so far in memory.
+//
+// False positives (a key reported as already seen when it wasn't) become
+// more likely as more distinct keys are inserted; false negatives never
+// occur. This makes it suitable for best-effort deduplication of
+// registered-domain streams in the billions, where exactness isn't worth
+// unbounded memory growth.
+type boundedBloomFilter struct {
+	bits []uint64
+}
+
+const (
+	// bloomBits is the filter's fixed size: 16Mi bits (~2MiB), which keeps
+	// the false-positive rate low for tens of millions of distinct keys.
+	bloomBits = 1 << 24
+	// bloomHashes is the number of bit positions set per key.
+	bloomHashes = 4
+)
+
+func newBoundedBloomFilter() *boundedBloomFilter {
+	return &boundedBloomFilter{bits: make([]uint64, bloomBits/64)}
+}
+
+// seenOrAdd reports whether key was (probably) already present, and
+// unconditionally marks it as seen for future calls.
+func (f *boundedBloomFilter) seenOrAdd(key string) bool {
+	h1, h2 := bloomHash(key)
+
+	seen := true
+	for i := uint64(0); i < bloomHashes; i++ {
+		idx := (h1 + i*h2) % bloomBits
+		word, bit := idx/64, idx%64
+		if f.bits[word]&(1<<bit) == 0 {
+			seen = false
+			f.bits[word] |= 1 << bit
+		}
+	}
+	return seen
+}
+
+// bloomHash derives two independent-enough hashes from key, combined via
+// double hashing (Kirsch-Mitzenmacher) to produce bloomHashes bit indices.
+func bloomHash(key string) (h1, h2 uint64) {
+	sum := fnv.New64a()
+	sum.Write([]byte(key))
+	h1 = sum.Sum64()
+	sum.Write([]byte{0})
+	h2 = sum.Sum64()
+	return h1, h2
+}
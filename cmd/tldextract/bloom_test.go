@@ -0,0 +1,43 @@
+package main
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestBoundedBloomFilterSeenOrAdd(t *testing.T) {
+	f := newBoundedBloomFilter()
+
+	if f.seenOrAdd("example.com") {
+		t.Error("seenOrAdd() = true on first insertion, want false")
+	}
+	if !f.seenOrAdd("example.com") {
+		t.Error("seenOrAdd() = false on second insertion, want true")
+	}
+	if f.seenOrAdd("other.com") {
+		t.Error("seenOrAdd() = true for a distinct key, want false")
+	}
+}
+
+// TestBoundedBloomFilterNoFalseNegatives inserts a batch of distinct keys,
+// then re-checks every one. A Bloom filter may report false positives, but
+// must never report a false negative: every key it has seen before must
+// come back seen.
+func TestBoundedBloomFilterNoFalseNegatives(t *testing.T) {
+	f := newBoundedBloomFilter()
+
+	keys := make([]string, 0, 10000)
+	for i := 0; i < 10000; i++ {
+		keys = append(keys, fmt.Sprintf("host-%d.example.com", i))
+	}
+
+	for _, k := range keys {
+		f.seenOrAdd(k)
+	}
+
+	for _, k := range keys {
+		if !f.seenOrAdd(k) {
+			t.Fatalf("seenOrAdd(%q) = false after insertion, want true (false negative)", k)
+		}
+	}
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestParseSelect(t *testing.T) {
+	tests := []struct {
+		name string
+		spec string
+		want []string
+	}{
+		{"default", "", []string{"registered"}},
+		{"single column", "domain", []string{"domain"}},
+		{"multiple columns preserve order", "tld,domain", []string{"tld", "domain"}},
+		{"duplicates removed", "domain,domain", []string{"domain"}},
+		{"case and whitespace normalized", " Domain , TLD ", []string{"domain", "tld"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseSelect(tt.spec)
+			if err != nil {
+				t.Fatalf("parseSelect(%q) error = %v", tt.spec, err)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("parseSelect(%q) = %v, want %v", tt.spec, got, tt.want)
+			}
+			for i := range tt.want {
+				if got[i] != tt.want[i] {
+					t.Errorf("parseSelect(%q) = %v, want %v", tt.spec, got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestParseSelectInvalidColumn(t *testing.T) {
+	if _, err := parseSelect("bogus"); err == nil {
+		t.Fatal("expected an error for an unknown column, got nil")
+	}
+}
+
+func TestColumnValue(t *testing.T) {
+	rec := &record{
+		Subdomain:  "www",
+		Domain:     "example",
+		TLD:        "com",
+		FQDN:       "www.example.com",
+		Registered: "example.com",
+	}
+
+	tests := map[string]string{
+		"subdomain":  "www",
+		"domain":     "example",
+		"tld":        "com",
+		"fqdn":       "www.example.com",
+		"registered": "example.com",
+	}
+	for col, want := range tests {
+		if got := columnValue(rec, col); got != want {
+			t.Errorf("columnValue(rec, %q) = %q, want %q", col, got, want)
+		}
+	}
+}
+
+func TestPlainWriterDefaultColumns(t *testing.T) {
+	var buf bytes.Buffer
+	w, err := newRecordWriter("plain", &buf, defaultColumns)
+	if err != nil {
+		t.Fatalf("newRecordWriter: %v", err)
+	}
+
+	if err := w.WriteRecord(&record{Input: "www.example.com", Registered: "example.com"}); err != nil {
+		t.Fatalf("WriteRecord: %v", err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	if got, want := buf.String(), "example.com\n"; got != want {
+		t.Errorf("plain output = %q, want %q", got, want)
+	}
+}
+
+func TestDelimitedWriterCSV(t *testing.T) {
+	var buf bytes.Buffer
+	w := newDelimitedWriter(&buf, []string{"domain", "tld"}, ',')
+
+	if err := w.WriteRecord(&record{Input: "www.example.com", Domain: "example", TLD: "com"}); err != nil {
+		t.Fatalf("WriteRecord: %v", err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimRight(buf.String(), "\n"), "\n")
+	if want := []string{"input,domain,tld", "www.example.com,example,com"}; len(lines) != len(want) || lines[0] != want[0] || lines[1] != want[1] {
+		t.Errorf("csv output = %v, want %v", lines, want)
+	}
+}
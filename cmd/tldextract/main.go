@@ -2,33 +2,32 @@ package main
 
 import (
 	"bufio"
-	"encoding/json"
+	"context"
 	"flag"
 	"fmt"
 	"io"
 	"log"
 	"os"
+	"os/signal"
+	"runtime"
 	"strings"
+	"sync/atomic"
+	"syscall"
 
 	"github.com/charlesblas/gotldextract"
 )
 
 var (
-	updateFlag = flag.Bool("update", false, "Update the public suffix list")
-	jsonFlag   = flag.Bool("json", false, "Output results as JSON")
-	csvFlag    = flag.Bool("csv", false, "Output results as CSV")
-	fileFlag   = flag.String("file", "", "Input file (default: stdin)")
-	helpFlag   = flag.Bool("help", false, "Show help")
+	updateFlag   = flag.Bool("update", false, "Update the public suffix list")
+	workersFlag  = flag.Int("workers", runtime.NumCPU(), "Number of concurrent extraction workers")
+	formatFlag   = flag.String("format", "plain", "Output format: ndjson, jsonl, csv, tsv, parquet, or plain")
+	selectFlag   = flag.String("select", "", "Comma-separated columns to output: subdomain,domain,tld,fqdn,registered (default: registered)")
+	uniqueFlag   = flag.String("unique", "", "Deduplicate records by this column using a bounded Bloom filter")
+	progressFlag = flag.Bool("progress", false, "Report processing rate/ETA to stderr")
+	fileFlag     = flag.String("file", "", "Input file (default: stdin)")
+	helpFlag     = flag.Bool("help", false, "Show help")
 )
 
-type JSONOutput struct {
-	Input     string `json:"input"`
-	Subdomain string `json:"subdomain"`
-	Domain    string `json:"domain"`
-	TLD       string `json:"tld"`
-	FQDN      string `json:"fqdn"`
-}
-
 func main() {
 	flag.Parse()
 
@@ -38,135 +37,139 @@ func main() {
 	}
 
 	if *updateFlag {
-		fmt.Println("Note: The public suffix list is embedded in the library.")
-		fmt.Println("To update it, please update the golang.org/x/net/publicsuffix package:")
-		fmt.Println("  go get -u golang.org/x/net/publicsuffix")
+		if err := gotldextract.Update(); err != nil {
+			log.Fatalf("Failed to update public suffix list: %v", err)
+		}
+		fmt.Println("Public suffix list updated.")
 		return
 	}
 
-	// Print CSV header if needed
-	if *csvFlag {
-		fmt.Println("input,subdomain,domain,tld,fqdn")
+	columns, err := parseSelect(*selectFlag)
+	if err != nil {
+		log.Fatalf("Invalid -select: %v", err)
 	}
-
-	// If there are command-line arguments (domains), process them
-	if flag.NArg() > 0 {
-		for _, domain := range flag.Args() {
-			processDomain(domain)
-		}
-		return
+	if *uniqueFlag != "" && !isValidColumn(*uniqueFlag) {
+		log.Fatalf("Invalid -unique %q (want one of %s)", *uniqueFlag, strings.Join(selectableColumns, ", "))
+	}
+	if *workersFlag < 1 {
+		log.Fatalf("Invalid -workers %d: must be at least 1", *workersFlag)
 	}
 
-	// Otherwise, read from file or stdin
-	var reader io.Reader
-	if *fileFlag != "" {
-		file, err := os.Open(*fileFlag)
-		if err != nil {
-			log.Fatalf("Failed to open file: %v", err)
-		}
-		defer file.Close()
-		reader = file
-	} else {
-		reader = os.Stdin
+	writer, err := newRecordWriter(*formatFlag, os.Stdout, columns)
+	if err != nil {
+		log.Fatalf("Invalid -format: %v", err)
 	}
 
-	scanner := bufio.NewScanner(reader)
-	writer := bufio.NewWriterSize(os.Stdout, 64*1024)
-	defer writer.Flush()
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
 
-	for scanner.Scan() {
-		line := strings.TrimSpace(scanner.Text())
-		if line == "" {
-			continue
-		}
-		
-		processDomainWithWriter(line, writer)
-	}
+	lines, totalBytes, readBytes, closeInput := inputLines(ctx)
+	defer closeInput()
 
-	if err := scanner.Err(); err != nil {
-		log.Fatalf("Error reading input: %v", err)
+	var progress *progressReporter
+	if *progressFlag {
+		progress = newProgressReporter(totalBytes, readBytes)
 	}
-}
 
-func processDomain(domain string) {
-	result, err := gotldextract.Extract(domain)
-	if err != nil {
-		log.Printf("Error processing %s: %v", domain, err)
-		return
+	var dedup *boundedBloomFilter
+	if *uniqueFlag != "" {
+		dedup = newBoundedBloomFilter()
 	}
 
-	if *jsonFlag {
-		output := JSONOutput{
-			Input:     domain,
-			Subdomain: result.Subdomain,
-			Domain:    result.Domain,
-			TLD:       result.TLD,
-			FQDN:      result.FQDN(),
+	out := func(rec *record) {
+		if dedup != nil && dedup.seenOrAdd(columnValue(rec, *uniqueFlag)) {
+			return
 		}
-		jsonBytes, _ := json.Marshal(output)
-		fmt.Println(string(jsonBytes))
-	} else if *csvFlag {
-		// Escape fields that contain commas or quotes
-		escapeCSV := func(s string) string {
-			if strings.Contains(s, ",") || strings.Contains(s, "\"") || strings.Contains(s, "\n") {
-				return fmt.Sprintf("\"%s\"", strings.ReplaceAll(s, "\"", "\"\""))
-			}
-			return s
+		if err := writer.WriteRecord(rec); err != nil {
+			log.Fatalf("Failed to write record: %v", err)
 		}
-		fmt.Printf("%s,%s,%s,%s,%s\n",
-			escapeCSV(domain),
-			escapeCSV(result.Subdomain),
-			escapeCSV(result.Domain),
-			escapeCSV(result.TLD),
-			escapeCSV(result.FQDN()))
-	} else {
-		fmt.Printf("Input: %s\n", domain)
-		fmt.Printf("  Subdomain: %s\n", result.Subdomain)
-		fmt.Printf("  Domain: %s\n", result.Domain)
-		fmt.Printf("  TLD: %s\n", result.TLD)
-		fmt.Printf("  FQDN: %s\n", result.FQDN())
-		fmt.Println()
+		if progress != nil {
+			progress.increment()
+		}
+	}
+
+	runPipeline(ctx, lines, *workersFlag, out)
+
+	if progress != nil {
+		progress.Stop()
+	}
+	if err := writer.Close(); err != nil {
+		log.Fatalf("Failed to flush output: %v", err)
 	}
 }
 
-func processDomainWithWriter(domain string, writer *bufio.Writer) {
-	result, err := gotldextract.Extract(domain)
-	if err != nil {
-		log.Printf("Error processing %s: %v", domain, err)
-		return
+// inputLines streams domains from the command-line arguments if any were
+// given, otherwise from -file or stdin. It returns the total input size in
+// bytes (0 if unknown, e.g. stdin or positional args) and a function
+// reporting bytes consumed so far, for progress ETA estimation.
+func inputLines(ctx context.Context) (lines <-chan string, totalBytes int64, readBytes func() int64, closeInput func() error) {
+	if flag.NArg() > 0 {
+		ch := make(chan string, flag.NArg())
+		for _, arg := range flag.Args() {
+			ch <- arg
+		}
+		close(ch)
+		return ch, 0, func() int64 { return 0 }, func() error { return nil }
 	}
 
-	if *jsonFlag {
-		output := JSONOutput{
-			Input:     domain,
-			Subdomain: result.Subdomain,
-			Domain:    result.Domain,
-			TLD:       result.TLD,
-			FQDN:      result.FQDN(),
+	var (
+		reader io.Reader
+		closer io.Closer = io.NopCloser(nil)
+	)
+	if *fileFlag != "" {
+		file, err := os.Open(*fileFlag)
+		if err != nil {
+			log.Fatalf("Failed to open file: %v", err)
 		}
-		jsonBytes, _ := json.Marshal(output)
-		writer.WriteString(string(jsonBytes))
-		writer.WriteByte('\n')
-	} else if *csvFlag {
-		// Escape fields that contain commas or quotes
-		escapeCSV := func(s string) string {
-			if strings.Contains(s, ",") || strings.Contains(s, "\"") || strings.Contains(s, "\n") {
-				return fmt.Sprintf("\"%s\"", strings.ReplaceAll(s, "\"", "\"\""))
-			}
-			return s
+		if info, err := file.Stat(); err == nil {
+			totalBytes = info.Size()
 		}
-		line := fmt.Sprintf("%s,%s,%s,%s,%s\n",
-			escapeCSV(domain),
-			escapeCSV(result.Subdomain),
-			escapeCSV(result.Domain),
-			escapeCSV(result.TLD),
-			escapeCSV(result.FQDN()))
-		writer.WriteString(line)
+		reader, closer = file, file
 	} else {
-		// For pipe mode, just output the extracted domain.tld
-		writer.WriteString(result.String())
-		writer.WriteByte('\n')
+		reader = os.Stdin
 	}
+
+	counting := &countingReader{r: reader}
+	ch := make(chan string, *workersFlag*4)
+
+	go func() {
+		defer close(ch)
+		scanner := bufio.NewScanner(counting)
+		scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+		for scanner.Scan() {
+			line := strings.TrimSpace(scanner.Text())
+			if line == "" {
+				continue
+			}
+			select {
+			case ch <- line:
+			case <-ctx.Done():
+				return
+			}
+		}
+		if err := scanner.Err(); err != nil {
+			log.Printf("Error reading input: %v", err)
+		}
+	}()
+
+	return ch, totalBytes, counting.bytesRead, closer.Close
+}
+
+// countingReader wraps an io.Reader to track how many bytes have been
+// consumed, so progress reporting can estimate how much input remains.
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	atomic.AddInt64(&c.n, int64(n))
+	return n, err
+}
+
+func (c *countingReader) bytesRead() int64 {
+	return atomic.LoadInt64(&c.n)
 }
 
 func printHelp() {
@@ -178,16 +181,19 @@ func printHelp() {
 	fmt.Println("  tldextract [options] < domains.txt")
 	fmt.Println()
 	fmt.Println("Options:")
-	fmt.Println("  -update    Update the public suffix list")
-	fmt.Println("  -json      Output results as JSON")
-	fmt.Println("  -csv       Output results as CSV")
-	fmt.Println("  -file      Read from file instead of stdin")
-	fmt.Println("  -help      Show this help message")
+	fmt.Println("  -update           Update the public suffix list")
+	fmt.Println("  -workers N        Number of concurrent extraction workers (default: NumCPU)")
+	fmt.Println("  -format FORMAT    Output format: ndjson, jsonl, csv, tsv, parquet, plain (default: plain)")
+	fmt.Println("  -select COLS      Comma-separated columns: subdomain,domain,tld,fqdn,registered (default: registered)")
+	fmt.Println("  -unique COL       Deduplicate records by COL using a bounded Bloom filter")
+	fmt.Println("  -progress         Report processing rate/ETA to stderr")
+	fmt.Println("  -file             Read from file instead of stdin")
+	fmt.Println("  -help             Show this help message")
 	fmt.Println()
 	fmt.Println("Examples:")
 	fmt.Println("  tldextract example.com")
-	fmt.Println("  tldextract -json https://www.example.co.uk")
-	fmt.Println("  tldextract -csv example.com api.github.com")
+	fmt.Println("  tldextract -format ndjson https://www.example.co.uk")
+	fmt.Println("  tldextract -format csv -select domain,tld example.com api.github.com")
 	fmt.Println("  echo 'subdomain.example.com' | tldextract")
-	fmt.Println("  tldextract -file domains.txt -csv > results.csv")
-}
\ No newline at end of file
+	fmt.Println("  tldextract -file domains.txt -workers 16 -unique registered -format parquet > results.parquet")
+}
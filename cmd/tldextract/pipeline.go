@@ -0,0 +1,115 @@
+package main
+
+import (
+	"context"
+	"log"
+	"sync"
+
+	"github.com/charlesblas/gotldextract"
+)
+
+// job is one input line paired with its position in the input stream, so
+// results can be put back in order after going through the worker pool.
+type job struct {
+	seq   int
+	input string
+}
+
+// result is a completed job: either a record ready to be written, or an
+// error to be logged.
+type result struct {
+	seq   int
+	input string
+	rec   *record
+	err   error
+}
+
+// runPipeline fans domains read from lines out across workers concurrent
+// calls to gotldextract.Extract, then hands the resulting records to out in
+// the same order they were read. It returns once lines is closed (or ctx is
+// cancelled) and every in-flight job has drained.
+func runPipeline(ctx context.Context, lines <-chan string, workers int, out func(*record)) {
+	jobs := make(chan job, workers*4)
+	results := make(chan result, workers*4)
+
+	var workerGroup sync.WaitGroup
+	workerGroup.Add(workers)
+	for i := 0; i < workers; i++ {
+		go func() {
+			defer workerGroup.Done()
+			for j := range jobs {
+				results <- extractOne(j)
+			}
+		}()
+	}
+
+	go func() {
+		defer close(jobs)
+		seq := 0
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case line, ok := <-lines:
+				if !ok {
+					return
+				}
+				jobs <- job{seq: seq, input: line}
+				seq++
+			}
+		}
+	}()
+
+	go func() {
+		workerGroup.Wait()
+		close(results)
+	}()
+
+	sequence(results, out)
+}
+
+func extractOne(j job) result {
+	r, err := gotldextract.Extract(j.input)
+	if err != nil {
+		return result{seq: j.seq, input: j.input, err: err}
+	}
+	return result{
+		seq:   j.seq,
+		input: j.input,
+		rec: &record{
+			Input:      j.input,
+			Subdomain:  r.Subdomain,
+			Domain:     r.Domain,
+			TLD:        r.TLD,
+			FQDN:       r.FQDN(),
+			Registered: r.String(),
+		},
+	}
+}
+
+// sequence reassembles results, which may arrive out of order since
+// workers race each other, back into job submission order before handing
+// each one to out.
+func sequence(results <-chan result, out func(*record)) {
+	pending := make(map[int]result)
+	next := 0
+
+	for res := range results {
+		pending[res.seq] = res
+
+		for {
+			res, ok := pending[next]
+			if !ok {
+				break
+			}
+			delete(pending, next)
+			next++
+
+			if res.err != nil {
+				log.Printf("Error processing %q: %v", res.input, res.err)
+				continue
+			}
+			out(res.rec)
+		}
+	}
+}
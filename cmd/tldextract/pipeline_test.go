@@ -0,0 +1,47 @@
+package main
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestSequenceReordersOutOfOrderResults(t *testing.T) {
+	results := make(chan result, 4)
+	// Feed results out of submission order; sequence must still emit them
+	// in seq order.
+	results <- result{seq: 2, input: "c.com", rec: &record{Input: "c.com", Registered: "c.com"}}
+	results <- result{seq: 0, input: "a.com", rec: &record{Input: "a.com", Registered: "a.com"}}
+	results <- result{seq: 1, input: "b.com", rec: &record{Input: "b.com", Registered: "b.com"}}
+	close(results)
+
+	var got []string
+	sequence(results, func(rec *record) {
+		got = append(got, rec.Registered)
+	})
+
+	want := []string{"a.com", "b.com", "c.com"}
+	if len(got) != len(want) {
+		t.Fatalf("sequence() emitted %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("sequence() emitted %v, want %v", got, want)
+		}
+	}
+}
+
+func TestSequenceSkipsErrors(t *testing.T) {
+	results := make(chan result, 2)
+	results <- result{seq: 0, input: "a.com", rec: &record{Input: "a.com", Registered: "a.com"}}
+	results <- result{seq: 1, input: "bad", err: errors.New("boom")}
+	close(results)
+
+	var got []string
+	sequence(results, func(rec *record) {
+		got = append(got, rec.Registered)
+	})
+
+	if len(got) != 1 || got[0] != "a.com" {
+		t.Errorf("sequence() = %v, want [a.com]", got)
+	}
+}
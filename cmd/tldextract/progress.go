@@ -0,0 +1,87 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"sync/atomic"
+	"time"
+)
+
+// progressReporter prints periodic rate/ETA updates to stderr while a
+// streaming extraction runs, so long exports don't look hung.
+type progressReporter struct {
+	start     time.Time
+	processed int64
+
+	// totalBytes and readBytes let the reporter estimate an ETA when the
+	// input is a regular file; when totalBytes is 0 (e.g. reading from
+	// stdin) the ETA is reported as unknown.
+	totalBytes int64
+	readBytes  func() int64
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+func newProgressReporter(totalBytes int64, readBytes func() int64) *progressReporter {
+	p := &progressReporter{
+		start:      time.Now(),
+		totalBytes: totalBytes,
+		readBytes:  readBytes,
+		stop:       make(chan struct{}),
+		done:       make(chan struct{}),
+	}
+	go p.run()
+	return p
+}
+
+// increment records that one more record has been processed.
+func (p *progressReporter) increment() {
+	atomic.AddInt64(&p.processed, 1)
+}
+
+func (p *progressReporter) run() {
+	defer close(p.done)
+
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-p.stop:
+			p.report()
+			return
+		case <-ticker.C:
+			p.report()
+		}
+	}
+}
+
+func (p *progressReporter) report() {
+	elapsed := time.Since(p.start).Seconds()
+	processed := atomic.LoadInt64(&p.processed)
+
+	rate := 0.0
+	if elapsed > 0 {
+		rate = float64(processed) / elapsed
+	}
+
+	eta := "unknown"
+	if p.totalBytes > 0 {
+		if consumed := p.readBytes(); consumed > 0 {
+			if fraction := float64(consumed) / float64(p.totalBytes); fraction > 0 {
+				remaining := time.Duration((elapsed/fraction - elapsed) * float64(time.Second))
+				eta = remaining.Round(time.Second).String()
+			}
+		}
+	}
+
+	fmt.Fprintf(os.Stderr, "\rprocessed=%d rate=%.0f/s eta=%s", processed, rate, eta)
+}
+
+// Stop halts the reporter, printing one final update.
+func (p *progressReporter) Stop() {
+	close(p.stop)
+	<-p.done
+	fmt.Fprintln(os.Stderr)
+}
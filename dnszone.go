@@ -0,0 +1,229 @@
+package gotldextract
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"net"
+	"os"
+	"strings"
+	"time"
+
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+// ErrNoAuthoritativeZone is returned when no nameserver in the walk from a
+// hostname up to the root answered with an SOA record.
+var ErrNoAuthoritativeZone = errors.New("gotldextract: no authoritative zone found")
+
+// defaultDNSTimeout bounds an SOA query when ZoneOptions.DNSTimeout isn't
+// set, so a silent or firewalled nameserver that drops the query can't
+// block the walk forever.
+const defaultDNSTimeout = 5 * time.Second
+
+// ZoneOptions configures FindRegisteredZone and FindAuthoritativeZone.
+type ZoneOptions struct {
+	// DNSTimeout bounds each individual SOA query issued while walking
+	// toward the zone apex. The zero value applies defaultDNSTimeout.
+	DNSTimeout time.Duration
+	// Server overrides the nameserver (host:port) that SOA queries are
+	// sent to. The zero value uses the first nameserver listed in
+	// /etc/resolv.conf, i.e. the system's configured recursive resolver.
+	Server string
+}
+
+// ZoneResult compares what the public suffix list says about a hostname
+// against what a live nameserver actually delegates, which can disagree
+// for vanity nameservers, split-horizon DNS, and internal zones the PSL
+// has no knowledge of.
+type ZoneResult struct {
+	// PSL is the result of extracting the hostname using the public
+	// suffix list alone, as Extract would.
+	PSL *Result
+	// Apex is the zone cut found by walking SOA queries from the hostname
+	// toward the root, without a trailing dot.
+	Apex string
+	// Mismatch is true when Apex disagrees with PSL's registered domain
+	// (PSL.String()).
+	Mismatch bool
+}
+
+// FindRegisteredZone resolves name's true DNS zone apex by issuing SOA
+// queries against resolver (or net.DefaultResolver, if nil), walking
+// labels from most specific to least specific until a query returns an
+// SOA record, whose owner name is taken as the apex, and compares it against what the bundled
+// public suffix list alone would say. This mirrors the technique ACME
+// DNS-01 clients use to find where a _acme-challenge record must be
+// created when the PSL disagrees with the actual delegation, e.g. because
+// of a vanity nameserver, split-horizon DNS, or an internal zone.
+func FindRegisteredZone(name string, resolver *net.Resolver, opts ZoneOptions) (*ZoneResult, error) {
+	return defaultExtractor.FindRegisteredZone(name, resolver, opts)
+}
+
+// FindRegisteredZone is like the package-level FindRegisteredZone, using
+// e's public suffix list for the PSL-derived comparison.
+func (e *Extractor) FindRegisteredZone(name string, resolver *net.Resolver, opts ZoneOptions) (*ZoneResult, error) {
+	pslResult, err := e.Extract(name)
+	if err != nil {
+		return nil, err
+	}
+
+	apex, err := FindAuthoritativeZone(name, resolver, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ZoneResult{
+		PSL:      pslResult,
+		Apex:     apex,
+		Mismatch: !strings.EqualFold(apex, pslResult.String()),
+	}, nil
+}
+
+// FindAuthoritativeZone resolves name's true DNS zone apex by issuing SOA
+// queries against resolver (or net.DefaultResolver, if nil), starting at
+// name itself and walking one label at a time toward the root until a
+// query returns an SOA record. Unlike FindRegisteredZone it does not
+// consult the public suffix list.
+func FindAuthoritativeZone(name string, resolver *net.Resolver, opts ZoneOptions) (string, error) {
+	host := strings.ToLower(strings.TrimSuffix(strings.TrimSpace(name), "."))
+	if host == "" {
+		return "", fmt.Errorf("gotldextract: empty hostname")
+	}
+	if resolver == nil {
+		resolver = net.DefaultResolver
+	}
+
+	server := opts.Server
+	if server == "" {
+		var err error
+		server, err = systemNameserver()
+		if err != nil {
+			return "", fmt.Errorf("gotldextract: determining nameserver: %w", err)
+		}
+	}
+
+	labels := strings.Split(host, ".")
+	for i := 0; i < len(labels); i++ {
+		candidate := strings.Join(labels[i:], ".")
+
+		apex, found, err := querySOA(resolver, server, candidate, opts.DNSTimeout)
+		if err != nil {
+			// A single unreachable or non-cooperative server along the
+			// walk shouldn't abort the search; try the next, less
+			// specific candidate.
+			continue
+		}
+		if found {
+			return strings.ToLower(strings.TrimSuffix(apex, ".")), nil
+		}
+	}
+
+	return "", ErrNoAuthoritativeZone
+}
+
+// querySOA issues a single SOA query for name against server, dialing
+// through resolver's Dial hook when set so tests can substitute a fake
+// nameserver connection. found is false, with a nil error, when the
+// server answered but had no SOA record for name (e.g. NXDOMAIN with no
+// authority section).
+func querySOA(resolver *net.Resolver, server, name string, timeout time.Duration) (apex string, found bool, err error) {
+	if timeout <= 0 {
+		timeout = defaultDNSTimeout
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	dial := resolver.Dial
+	if dial == nil {
+		dial = (&net.Dialer{}).DialContext
+	}
+
+	conn, err := dial(ctx, "udp", server)
+	if err != nil {
+		return "", false, fmt.Errorf("gotldextract: dialing nameserver: %w", err)
+	}
+	defer conn.Close()
+
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+
+	qname, err := dnsmessage.NewName(name + ".")
+	if err != nil {
+		return "", false, fmt.Errorf("gotldextract: encoding %q as a DNS name: %w", name, err)
+	}
+	queryID := uint16(rand.Intn(1 << 16))
+	query := dnsmessage.Message{
+		Header: dnsmessage.Header{ID: queryID, RecursionDesired: true},
+		Questions: []dnsmessage.Question{{
+			Name:  qname,
+			Type:  dnsmessage.TypeSOA,
+			Class: dnsmessage.ClassINET,
+		}},
+	}
+	packed, err := query.Pack()
+	if err != nil {
+		return "", false, fmt.Errorf("gotldextract: building SOA query: %w", err)
+	}
+	if _, err := conn.Write(packed); err != nil {
+		return "", false, fmt.Errorf("gotldextract: sending SOA query: %w", err)
+	}
+
+	buf := make([]byte, 512)
+	n, err := conn.Read(buf)
+	if err != nil {
+		return "", false, fmt.Errorf("gotldextract: reading SOA response: %w", err)
+	}
+
+	var response dnsmessage.Message
+	if err := response.Unpack(buf[:n]); err != nil {
+		return "", false, fmt.Errorf("gotldextract: parsing SOA response: %w", err)
+	}
+
+	// Reject responses that don't echo our query ID and question: without
+	// this, a spoofed or stray UDP packet from anyone who can race the
+	// real answer would be accepted as if it came from server.
+	if response.Header.ID != queryID {
+		return "", false, fmt.Errorf("gotldextract: SOA response ID mismatch (got %d, want %d)", response.Header.ID, queryID)
+	}
+	if len(response.Questions) != 1 || !strings.EqualFold(response.Questions[0].Name.String(), qname.String()) {
+		return "", false, fmt.Errorf("gotldextract: SOA response question mismatch")
+	}
+
+	// A recursive resolver returns the owning zone's SOA record, named
+	// for its apex, in the answer section when asked about the apex
+	// itself and in the authority section when asked about a name below
+	// it (or one that doesn't exist) - either way its owner name is the
+	// zone cut we're looking for.
+	for _, section := range [][]dnsmessage.Resource{response.Answers, response.Authorities} {
+		for _, res := range section {
+			if res.Header.Type == dnsmessage.TypeSOA {
+				return res.Header.Name.String(), true, nil
+			}
+		}
+	}
+	return "", false, nil
+}
+
+// systemNameserver returns the first nameserver listed in /etc/resolv.conf,
+// used as the recursive resolver SOA queries are sent to when
+// ZoneOptions.Server isn't set.
+func systemNameserver() (string, error) {
+	data, err := os.ReadFile("/etc/resolv.conf")
+	if err != nil {
+		return "", err
+	}
+	for _, line := range strings.Split(string(data), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) >= 2 && fields[0] == "nameserver" {
+			ip := fields[1]
+			if strings.Contains(ip, ":") {
+				return "[" + ip + "]:53", nil
+			}
+			return ip + ":53", nil
+		}
+	}
+	return "", errors.New("no nameserver configured in /etc/resolv.conf")
+}
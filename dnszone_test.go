@@ -0,0 +1,212 @@
+package gotldextract
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+// fakeSOAServer is a minimal UDP nameserver that answers every SOA query
+// with a canned SOA record owned by apex, placed in the authority section
+// like a real recursive resolver answering for a name below the zone cut.
+func fakeSOAServer(t *testing.T, apex string) string {
+	t.Helper()
+
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listening: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	go func() {
+		buf := make([]byte, 512)
+		for {
+			n, addr, err := conn.ReadFrom(buf)
+			if err != nil {
+				return
+			}
+
+			var query dnsmessage.Message
+			if err := query.Unpack(buf[:n]); err != nil {
+				continue
+			}
+
+			owner, err := dnsmessage.NewName(apex + ".")
+			if err != nil {
+				continue
+			}
+			response := dnsmessage.Message{
+				Header: dnsmessage.Header{
+					ID:       query.Header.ID,
+					Response: true,
+				},
+				Questions: query.Questions,
+				Authorities: []dnsmessage.Resource{
+					{
+						Header: dnsmessage.ResourceHeader{
+							Name:  owner,
+							Type:  dnsmessage.TypeSOA,
+							Class: dnsmessage.ClassINET,
+							TTL:   3600,
+						},
+						Body: &dnsmessage.SOAResource{
+							NS:   owner,
+							MBox: owner,
+						},
+					},
+				},
+			}
+
+			packed, err := response.Pack()
+			if err != nil {
+				continue
+			}
+			conn.WriteTo(packed, addr)
+		}
+	}()
+
+	return conn.LocalAddr().String()
+}
+
+func TestFindAuthoritativeZone(t *testing.T) {
+	server := fakeSOAServer(t, "example.co.uk")
+
+	apex, err := FindAuthoritativeZone(
+		"_acme-challenge.api.staging.example.co.uk",
+		nil,
+		ZoneOptions{Server: server, DNSTimeout: 2 * time.Second},
+	)
+	if err != nil {
+		t.Fatalf("FindAuthoritativeZone: %v", err)
+	}
+	if apex != "example.co.uk" {
+		t.Errorf("apex = %q, want %q", apex, "example.co.uk")
+	}
+}
+
+func TestFindRegisteredZoneMismatch(t *testing.T) {
+	// A vanity/internal zone cut the PSL has no knowledge of: the PSL
+	// sees "staging.example.co.uk" as "example.co.uk", but the live
+	// nameserver delegates at "staging.example.co.uk" itself.
+	server := fakeSOAServer(t, "staging.example.co.uk")
+
+	zone, err := FindRegisteredZone(
+		"api.staging.example.co.uk",
+		nil,
+		ZoneOptions{Server: server, DNSTimeout: 2 * time.Second},
+	)
+	if err != nil {
+		t.Fatalf("FindRegisteredZone: %v", err)
+	}
+	if zone.PSL.String() != "example.co.uk" {
+		t.Errorf("PSL.String() = %q, want %q", zone.PSL.String(), "example.co.uk")
+	}
+	if zone.Apex != "staging.example.co.uk" {
+		t.Errorf("Apex = %q, want %q", zone.Apex, "staging.example.co.uk")
+	}
+	if !zone.Mismatch {
+		t.Error("Mismatch = false, want true")
+	}
+}
+
+func TestFindRegisteredZoneNoMismatch(t *testing.T) {
+	server := fakeSOAServer(t, "example.com")
+
+	zone, err := FindRegisteredZone(
+		"www.example.com",
+		nil,
+		ZoneOptions{Server: server, DNSTimeout: 2 * time.Second},
+	)
+	if err != nil {
+		t.Fatalf("FindRegisteredZone: %v", err)
+	}
+	if zone.Mismatch {
+		t.Errorf("Mismatch = true, want false (PSL=%q, Apex=%q)", zone.PSL.String(), zone.Apex)
+	}
+}
+
+// mismatchedIDServer answers every query with an SOA record, but with a
+// response ID that never matches the query's, simulating a spoofed or
+// stray UDP packet from someone other than the real nameserver.
+func mismatchedIDServer(t *testing.T, apex string) string {
+	t.Helper()
+
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listening: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	go func() {
+		buf := make([]byte, 512)
+		for {
+			n, addr, err := conn.ReadFrom(buf)
+			if err != nil {
+				return
+			}
+
+			var query dnsmessage.Message
+			if err := query.Unpack(buf[:n]); err != nil {
+				continue
+			}
+
+			owner, err := dnsmessage.NewName(apex + ".")
+			if err != nil {
+				continue
+			}
+			response := dnsmessage.Message{
+				Header: dnsmessage.Header{
+					ID:       query.Header.ID + 1,
+					Response: true,
+				},
+				Questions: query.Questions,
+				Authorities: []dnsmessage.Resource{
+					{
+						Header: dnsmessage.ResourceHeader{
+							Name:  owner,
+							Type:  dnsmessage.TypeSOA,
+							Class: dnsmessage.ClassINET,
+							TTL:   3600,
+						},
+						Body: &dnsmessage.SOAResource{
+							NS:   owner,
+							MBox: owner,
+						},
+					},
+				},
+			}
+
+			packed, err := response.Pack()
+			if err != nil {
+				continue
+			}
+			conn.WriteTo(packed, addr)
+		}
+	}()
+
+	return conn.LocalAddr().String()
+}
+
+func TestFindAuthoritativeZoneRejectsMismatchedResponseID(t *testing.T) {
+	server := mismatchedIDServer(t, "example.com")
+
+	_, err := FindAuthoritativeZone("www.example.com", nil, ZoneOptions{
+		Server:     server,
+		DNSTimeout: 2 * time.Second,
+	})
+	if err == nil {
+		t.Fatal("expected an error for a response with a mismatched ID, got nil")
+	}
+}
+
+func TestFindAuthoritativeZoneNoServer(t *testing.T) {
+	_, err := FindAuthoritativeZone("example.com", nil, ZoneOptions{
+		Server:     "127.0.0.1:1",
+		DNSTimeout: 200 * time.Millisecond,
+	})
+	if err == nil {
+		t.Fatal("expected an error querying an unreachable nameserver, got nil")
+	}
+}
@@ -0,0 +1,89 @@
+package gotldextract
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Sentinel errors returned (wrapped in a *LabelError, except ErrTotalTooLong)
+// when a domain fails strict RFC 1035/5890 validation.
+var (
+	// ErrEmptyLabel means two dots appeared back-to-back, or the domain
+	// began or ended with a dot, producing a zero-length label.
+	ErrEmptyLabel = errors.New("gotldextract: empty label")
+	// ErrLabelTooLong means a label exceeded the 63 octet limit.
+	ErrLabelTooLong = errors.New("gotldextract: label exceeds 63 octets")
+	// ErrTotalTooLong means the domain exceeded the 253 octet limit.
+	ErrTotalTooLong = errors.New("gotldextract: domain exceeds 253 octets")
+	// ErrInvalidChar means a label contained a character outside the LDH
+	// (letter/digit/hyphen) set, or had a leading/trailing hyphen.
+	ErrInvalidChar = errors.New("gotldextract: label contains an invalid character")
+)
+
+// LabelError reports a validation failure for a single label (a
+// dot-separated segment) of a domain name.
+type LabelError struct {
+	// Err is one of ErrEmptyLabel, ErrLabelTooLong, or ErrInvalidChar.
+	Err error
+	// Label is the offending label's text.
+	Label string
+	// LabelIndex is the zero-based position of the offending label,
+	// counted from the left (most-specific) end of the domain.
+	LabelIndex int
+}
+
+func (e *LabelError) Error() string {
+	return fmt.Sprintf("gotldextract: label %d (%q): %v", e.LabelIndex, e.Label, e.Err)
+}
+
+func (e *LabelError) Unwrap() error {
+	return e.Err
+}
+
+// validateFQDN checks fqdn (no trailing dot) against RFC 1035/5890: no
+// empty labels, no label over 63 octets, no more than 253 octets total,
+// and only LDH (letter/digit/hyphen, no leading/trailing hyphen)
+// characters in each label.
+func validateFQDN(fqdn string) error {
+	if len(fqdn) > 253 {
+		return ErrTotalTooLong
+	}
+
+	labels := strings.Split(fqdn, ".")
+	for i, label := range labels {
+		if label == "" {
+			return &LabelError{Err: ErrEmptyLabel, Label: label, LabelIndex: i}
+		}
+		if len(label) > 63 {
+			return &LabelError{Err: ErrLabelTooLong, Label: label, LabelIndex: i}
+		}
+		if label[0] == '-' || label[len(label)-1] == '-' {
+			return &LabelError{Err: ErrInvalidChar, Label: label, LabelIndex: i}
+		}
+		for _, r := range label {
+			if !isLDHRune(r) {
+				return &LabelError{Err: ErrInvalidChar, Label: label, LabelIndex: i}
+			}
+		}
+	}
+
+	return nil
+}
+
+// isLDHRune reports whether r is a valid LDH (letter/digit/hyphen)
+// character.
+func isLDHRune(r rune) bool {
+	switch {
+	case r >= 'a' && r <= 'z':
+		return true
+	case r >= 'A' && r <= 'Z':
+		return true
+	case r >= '0' && r <= '9':
+		return true
+	case r == '-':
+		return true
+	default:
+		return false
+	}
+}
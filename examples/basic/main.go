@@ -40,7 +40,6 @@ func main() {
 	}
 
 	// Note about updates
-	fmt.Println("Note: The public suffix list is embedded in the library.")
-	fmt.Println("To get the latest TLD data, update the package with:")
-	fmt.Println("  go get -u golang.org/x/net/publicsuffix")
+	fmt.Println("Note: gotldextract ships with a bundled public suffix list.")
+	fmt.Println("To refresh it from publicsuffix.org at runtime, call gotldextract.Update().")
 }
\ No newline at end of file
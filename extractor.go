@@ -0,0 +1,185 @@
+package gotldextract
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/charlesblas/gotldextract/internal/psl"
+)
+
+// DefaultSuffixListURL is the upstream location Update fetches the public
+// suffix list from.
+const DefaultSuffixListURL = "https://publicsuffix.org/list/public_suffix_list.dat"
+
+var (
+	defaultSnapshotOnce sync.Once
+	defaultSnapshotVal  *suffixListSnapshot
+)
+
+// defaultSnapshot lazily parses the public suffix list bundled with the
+// library, so extractors that never load a custom list still work out of
+// the box.
+func defaultSnapshot() *suffixListSnapshot {
+	defaultSnapshotOnce.Do(func() {
+		snap, err := parseSuffixList(strings.NewReader(psl.Default))
+		if err != nil {
+			panic("gotldextract: embedded public suffix list is invalid: " + err.Error())
+		}
+		defaultSnapshotVal = snap
+	})
+	return defaultSnapshotVal
+}
+
+// Extractor extracts subdomain/domain/TLD parts using a pluggable,
+// refreshable public suffix list. The zero value is not usable; create one
+// with NewExtractor, NewExtractorFromReader, or NewExtractorFromSuffixList.
+type Extractor struct {
+	base  atomic.Pointer[SuffixList]
+	extra atomic.Pointer[suffixListSnapshot]
+
+	extraMu sync.Mutex
+}
+
+// NewExtractor creates an Extractor using the public suffix list bundled
+// with gotldextract. Call Update or LoadSuffixes to refresh it at runtime.
+func NewExtractor() *Extractor {
+	e := &Extractor{}
+	e.setBase(defaultSnapshot())
+	return e
+}
+
+// NewExtractorFromReader creates an Extractor whose public suffix list is
+// parsed from r instead of the bundled default. This is useful in tests
+// and air-gapped deployments that supply their own copy of the list.
+func NewExtractorFromReader(r io.Reader) (*Extractor, error) {
+	snap, err := parseSuffixList(r)
+	if err != nil {
+		return nil, fmt.Errorf("gotldextract: parsing suffix list: %w", err)
+	}
+	e := &Extractor{}
+	e.setBase(snap)
+	return e, nil
+}
+
+// NewExtractorFromSuffixList creates an Extractor backed by list instead of
+// a list parsed from PSL text, letting callers supply their own lookup
+// logic (a database-backed list, computed rules, etc.) in place of the
+// bundled or file-loaded ones. AddSuffix/AddPrivateSuffix still layer their
+// additions on top of list, and LoadSuffixes/Update still replace it with
+// a snapshot parsed from PSL text if called afterward.
+func NewExtractorFromSuffixList(list SuffixList) *Extractor {
+	e := &Extractor{}
+	e.setBase(list)
+	return e
+}
+
+// setBase atomically swaps in list as e's base public suffix list.
+func (e *Extractor) setBase(list SuffixList) {
+	e.base.Store(&list)
+}
+
+// AddSuffix registers an additional ICANN-equivalent public suffix, such as
+// an internal zone like "corp.example", that Extract should treat as a TLD
+// boundary.
+func (e *Extractor) AddSuffix(suffix string) {
+	e.addExtra(suffix, false)
+}
+
+// AddPrivateSuffix registers an additional private (non-ICANN) suffix,
+// such as a custom PaaS domain in the style of "github.io".
+func (e *Extractor) AddPrivateSuffix(suffix string) {
+	e.addExtra(suffix, true)
+}
+
+func (e *Extractor) addExtra(suffix string, private bool) {
+	suffix = strings.ToLower(strings.TrimSuffix(suffix, "."))
+
+	e.extraMu.Lock()
+	defer e.extraMu.Unlock()
+
+	rules := make(map[string]pslRule)
+	if existing := e.extra.Load(); existing != nil {
+		for text, rule := range existing.rules {
+			rules[text] = rule
+		}
+	}
+	rules[suffix] = pslRule{kind: ruleNormal, private: private}
+	e.extra.Store(&suffixListSnapshot{rules: rules})
+}
+
+// LoadSuffixes replaces the extractor's public suffix list by loading it
+// from a local file path or an http(s):// URL, and atomically swaps it in.
+// Remote URLs are cached on disk; see Update.
+func (e *Extractor) LoadSuffixes(source string) error {
+	var (
+		data []byte
+		err  error
+	)
+	if strings.HasPrefix(source, "http://") || strings.HasPrefix(source, "https://") {
+		data, err = fetchSuffixList(source)
+	} else {
+		data, err = os.ReadFile(source)
+	}
+	if err != nil {
+		return fmt.Errorf("gotldextract: loading suffix list from %s: %w", source, err)
+	}
+
+	snap, err := parseSuffixList(bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("gotldextract: parsing suffix list from %s: %w", source, err)
+	}
+
+	e.setBase(snap)
+	return nil
+}
+
+// Update refreshes the extractor's public suffix list from
+// DefaultSuffixListURL, using a local cache with an ETag check so
+// unchanged lists aren't re-downloaded, and atomically swaps in the
+// parsed result.
+func (e *Extractor) Update() error {
+	return e.LoadSuffixes(DefaultSuffixListURL)
+}
+
+// publicSuffix resolves domain's public suffix, merging suffixes added via
+// AddSuffix/AddPrivateSuffix with e's base SuffixList and keeping the
+// longer (more specific) match, per the PSL algorithm, rather than letting
+// either list shadow a more specific match in the other. The base list
+// always has an answer (falling back to the implicit "*" rule per
+// SuffixList.PublicSuffix's contract), so only extra needs a found/not
+// found signal to decide whether it should win.
+func (e *Extractor) publicSuffix(domain string, set SuffixSet) (suffix string, icann bool) {
+	baseList := *e.base.Load()
+	baseSuffix, baseICANN := baseList.PublicSuffix(domain, set)
+
+	extra := e.extra.Load()
+	if extra == nil {
+		return baseSuffix, baseICANN
+	}
+	extraSuffix, extraICANN, extraFound := extra.lookup(domain, set)
+	if !extraFound {
+		return baseSuffix, baseICANN
+	}
+	if strings.Count(extraSuffix, ".") >= strings.Count(baseSuffix, ".") {
+		return extraSuffix, extraICANN
+	}
+	return baseSuffix, baseICANN
+}
+
+// Extract parses a domain/URL and extracts its parts using e's public
+// suffix list.
+func (e *Extractor) Extract(domain string) (*Result, error) {
+	return e.ExtractWithOptions(domain, ExtractOptions{})
+}
+
+// ExtractStrict parses a domain/URL like Extract, but first validates it
+// against RFC 1035/5890 FQDN syntax, returning a *LabelError or
+// ErrTotalTooLong if the domain is malformed.
+func (e *Extractor) ExtractStrict(domain string) (*Result, error) {
+	return e.ExtractWithOptions(domain, ExtractOptions{Strict: true})
+}
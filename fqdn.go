@@ -0,0 +1,37 @@
+package gotldextract
+
+import "strings"
+
+// FQDN is a domain name that has passed strict RFC 1035/5890 validation,
+// typed to keep validated and unvalidated strings from being mixed up at
+// compile time.
+type FQDN string
+
+// ToFQDN validates s as an RFC 1035/5890 domain name and returns it as a
+// typed FQDN. A single trailing dot (denoting an absolute name) is
+// permitted and preserved.
+func ToFQDN(s string) (FQDN, error) {
+	if err := validateFQDN(strings.TrimSuffix(s, ".")); err != nil {
+		return "", err
+	}
+	return FQDN(s), nil
+}
+
+// WithTrailingDot returns f with a trailing dot appended, if it doesn't
+// already have one.
+func (f FQDN) WithTrailingDot() FQDN {
+	if strings.HasSuffix(string(f), ".") {
+		return f
+	}
+	return f + "."
+}
+
+// WithoutTrailingDot returns f with any trailing dot removed.
+func (f FQDN) WithoutTrailingDot() FQDN {
+	return FQDN(strings.TrimSuffix(string(f), "."))
+}
+
+// String returns f as a plain string.
+func (f FQDN) String() string {
+	return string(f)
+}
@@ -0,0 +1,9 @@
+// Package psl embeds the bundled default copy of the Mozilla Public
+// Suffix List that gotldextract ships so it works out of the box without
+// a network fetch.
+package psl
+
+import _ "embed"
+
+//go:embed public_suffix_list.dat
+var Default string
@@ -0,0 +1,155 @@
+package gotldextract
+
+import (
+	"bufio"
+	"io"
+	"strings"
+)
+
+// SuffixSet selects which sections of a SuffixList Extract should consult.
+type SuffixSet int
+
+const (
+	// SuffixICANNAndPrivate matches both ICANN-delegated suffixes (e.g.
+	// "co.uk") and privately contributed ones (e.g. "github.io"). This is
+	// the default.
+	SuffixICANNAndPrivate SuffixSet = iota
+	// SuffixICANNOnly matches only suffixes delegated by ICANN, ignoring
+	// privately contributed entries.
+	SuffixICANNOnly
+	// SuffixPrivateOnly matches only privately contributed suffixes.
+	SuffixPrivateOnly
+)
+
+func (s SuffixSet) allows(private bool) bool {
+	switch s {
+	case SuffixICANNOnly:
+		return !private
+	case SuffixPrivateOnly:
+		return private
+	default:
+		return true
+	}
+}
+
+// SuffixList resolves the public suffix (effective TLD) of a domain name.
+// Implementations must be safe for concurrent use.
+type SuffixList interface {
+	// PublicSuffix returns the longest public suffix of domain (a
+	// lowercase, dot-separated name with no trailing dot) found under the
+	// given SuffixSet, and whether the match came from the ICANN section
+	// of the list as opposed to a private one or no match at all. When no
+	// rule matches, the suffix is domain's last label (the PSL's implicit
+	// "*" rule).
+	PublicSuffix(domain string, set SuffixSet) (suffix string, icann bool)
+}
+
+type ruleKind uint8
+
+const (
+	ruleNormal ruleKind = iota
+	ruleWildcard
+	ruleException
+)
+
+type pslRule struct {
+	kind    ruleKind
+	private bool
+}
+
+// suffixListSnapshot is an immutable, parsed public suffix list. Extractor
+// swaps its active snapshot atomically so a lookup never observes a
+// partially loaded list.
+type suffixListSnapshot struct {
+	rules map[string]pslRule
+}
+
+// parseSuffixList parses a public suffix list in the format published at
+// https://publicsuffix.org/list/, including its "===BEGIN/END ICANN/PRIVATE
+// DOMAINS===" section markers, wildcard ("*.foo") rules, and exception
+// ("!foo.bar") rules.
+func parseSuffixList(r io.Reader) (*suffixListSnapshot, error) {
+	rules := make(map[string]pslRule)
+	private := false
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+
+		switch {
+		case line == "":
+			continue
+		case strings.Contains(line, "===BEGIN ICANN DOMAINS==="):
+			private = false
+			continue
+		case strings.Contains(line, "===BEGIN PRIVATE DOMAINS==="):
+			private = true
+			continue
+		case strings.HasPrefix(line, "//"):
+			continue
+		}
+
+		rule := pslRule{private: private}
+		text := line
+		switch {
+		case strings.HasPrefix(line, "*."):
+			rule.kind = ruleWildcard
+			text = line[2:]
+		case strings.HasPrefix(line, "!"):
+			rule.kind = ruleException
+			text = line[1:]
+		}
+
+		rules[strings.ToLower(text)] = rule
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return &suffixListSnapshot{rules: rules}, nil
+}
+
+// lookup finds the prevailing rule for domain, trying candidates from the
+// most specific (the whole domain) to the least specific (its last label)
+// and returning the first one that matches, which per the PSL algorithm
+// is always the longest match. found is false if no rule in the list
+// matched at all.
+func (s *suffixListSnapshot) lookup(domain string, set SuffixSet) (suffix string, icann bool, found bool) {
+	labels := strings.Split(domain, ".")
+	for i := range labels {
+		candidate := strings.Join(labels[i:], ".")
+
+		rule, ok := s.rules[candidate]
+		if !ok || !set.allows(rule.private) {
+			continue
+		}
+
+		switch rule.kind {
+		case ruleWildcard:
+			if i == 0 {
+				// The wildcard needs one more label to its left.
+				continue
+			}
+			return strings.Join(labels[i-1:], "."), !rule.private, true
+		case ruleException:
+			// The public suffix is the matched rule minus its leftmost label.
+			return strings.Join(labels[i+1:], "."), !rule.private, true
+		default:
+			return candidate, !rule.private, true
+		}
+	}
+
+	return "", false, false
+}
+
+// PublicSuffix implements SuffixList.
+func (s *suffixListSnapshot) PublicSuffix(domain string, set SuffixSet) (string, bool) {
+	if suffix, icann, ok := s.lookup(domain, set); ok {
+		return suffix, icann
+	}
+
+	// No rule matched; per the PSL algorithm the prevailing rule is the
+	// implicit "*", i.e. the last label is treated as the suffix.
+	labels := strings.Split(domain, ".")
+	return labels[len(labels)-1], false
+}
@@ -0,0 +1,135 @@
+package gotldextract
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseSuffixListWildcardAndException(t *testing.T) {
+	const list = `
+// ===BEGIN ICANN DOMAINS===
+jp
+*.kawasaki.jp
+!city.kawasaki.jp
+// ===END ICANN DOMAINS===
+
+// ===BEGIN PRIVATE DOMAINS===
+github.io
+// ===END PRIVATE DOMAINS===
+`
+
+	snap, err := parseSuffixList(strings.NewReader(list))
+	if err != nil {
+		t.Fatalf("parseSuffixList() error = %v", err)
+	}
+
+	tests := []struct {
+		domain     string
+		wantSuffix string
+		wantICANN  bool
+	}{
+		{"example.jp", "jp", true},
+		{"foo.kawasaki.jp", "foo.kawasaki.jp", true},
+		{"city.kawasaki.jp", "kawasaki.jp", true},
+		{"www.city.kawasaki.jp", "kawasaki.jp", true},
+		{"example.github.io", "github.io", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.domain, func(t *testing.T) {
+			suffix, icann := snap.PublicSuffix(tt.domain, SuffixICANNAndPrivate)
+			if suffix != tt.wantSuffix {
+				t.Errorf("PublicSuffix() suffix = %v, want %v", suffix, tt.wantSuffix)
+			}
+			if icann != tt.wantICANN {
+				t.Errorf("PublicSuffix() icann = %v, want %v", icann, tt.wantICANN)
+			}
+		})
+	}
+}
+
+func TestExtractorSuffixSet(t *testing.T) {
+	const list = `
+// ===BEGIN ICANN DOMAINS===
+com
+// ===END ICANN DOMAINS===
+
+// ===BEGIN PRIVATE DOMAINS===
+github.io
+// ===END PRIVATE DOMAINS===
+`
+
+	extractor, err := NewExtractorFromReader(strings.NewReader(list))
+	if err != nil {
+		t.Fatalf("NewExtractorFromReader() error = %v", err)
+	}
+
+	result, err := extractor.ExtractWithOptions("example.github.io", ExtractOptions{Suffixes: SuffixICANNOnly})
+	if err != nil {
+		t.Fatalf("ExtractWithOptions() error = %v", err)
+	}
+	// With ICANN-only resolution the private "github.io" rule is ignored,
+	// so the effective suffix falls back to the last label.
+	if got, want := result.TLD, "io"; got != want {
+		t.Errorf("TLD = %v, want %v", got, want)
+	}
+
+	result, err = extractor.ExtractWithOptions("example.github.io", ExtractOptions{Suffixes: SuffixICANNAndPrivate})
+	if err != nil {
+		t.Fatalf("ExtractWithOptions() error = %v", err)
+	}
+	if got, want := result.TLD, "github.io"; got != want {
+		t.Errorf("TLD = %v, want %v", got, want)
+	}
+}
+
+func TestExtractorAddSuffix(t *testing.T) {
+	extractor := NewExtractor()
+	extractor.AddSuffix("corp.example")
+	extractor.AddPrivateSuffix("apps.internal")
+
+	result, err := extractor.Extract("api.service.corp.example")
+	if err != nil {
+		t.Fatalf("Extract() error = %v", err)
+	}
+	if got, want := result.TLD, "corp.example"; got != want {
+		t.Errorf("TLD = %v, want %v", got, want)
+	}
+	if got, want := result.Domain, "service"; got != want {
+		t.Errorf("Domain = %v, want %v", got, want)
+	}
+
+	result, err = extractor.Extract("www.apps.internal")
+	if err != nil {
+		t.Fatalf("Extract() error = %v", err)
+	}
+	if got, want := result.TLD, "apps.internal"; got != want {
+		t.Errorf("TLD = %v, want %v", got, want)
+	}
+}
+
+// fixedSuffixList is a minimal SuffixList that always reports a single
+// fixed suffix, used to prove Extractor can be driven by a caller-supplied
+// implementation instead of a PSL-text-backed suffixListSnapshot.
+type fixedSuffixList struct {
+	suffix string
+}
+
+func (f fixedSuffixList) PublicSuffix(domain string, set SuffixSet) (string, bool) {
+	return f.suffix, true
+}
+
+func TestNewExtractorFromSuffixList(t *testing.T) {
+	extractor := NewExtractorFromSuffixList(fixedSuffixList{suffix: "internal"})
+
+	result, err := extractor.Extract("api.service.internal")
+	if err != nil {
+		t.Fatalf("Extract() error = %v", err)
+	}
+	if got, want := result.TLD, "internal"; got != want {
+		t.Errorf("TLD = %v, want %v", got, want)
+	}
+	if got, want := result.Domain, "service"; got != want {
+		t.Errorf("Domain = %v, want %v", got, want)
+	}
+}
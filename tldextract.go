@@ -1,67 +1,186 @@
 package gotldextract
 
 import (
-	"fmt"
 	"strings"
 
-	"golang.org/x/net/publicsuffix"
+	"golang.org/x/net/idna"
 )
 
+// IDNAMode selects how internationalized domain names are normalized before
+// they are split into parts.
+type IDNAMode int
+
+const (
+	// IDNAPreserve extracts the domain exactly as it was given, without any
+	// Punycode encoding or decoding. This is the default.
+	IDNAPreserve IDNAMode = iota
+	// IDNAToASCII normalizes the domain to its ASCII (Punycode, "xn--")
+	// form before extraction.
+	IDNAToASCII
+	// IDNAToUnicode decodes the domain to its Unicode form before
+	// extraction.
+	IDNAToUnicode
+)
+
+// ExtractOptions configures how Extract processes a domain.
+type ExtractOptions struct {
+	// IDNAMode selects the IDNA normalization applied to the domain before
+	// it is split into subdomain/domain/TLD parts. The zero value is
+	// IDNAPreserve.
+	IDNAMode IDNAMode
+	// Transitional is passed to the IDNA profile as its UTS-46
+	// transitional (IDNA2003 compatible) setting. That profile applies no
+	// UTS-46 mapping step, which is where transitional processing takes
+	// effect, so the setting does not change any result: deviation
+	// characters such as "ß" are Punycode-encoded as given in either mode.
+	Transitional bool
+	// Strict validates the domain against RFC 1035/5890 FQDN syntax
+	// before extraction, returning a *LabelError or ErrTotalTooLong if it
+	// is malformed.
+	Strict bool
+	// Suffixes selects which sections of the public suffix list (ICANN,
+	// private, or both) are consulted. The zero value is
+	// SuffixICANNAndPrivate.
+	Suffixes SuffixSet
+}
+
 // Result represents the extracted parts of a domain
 type Result struct {
 	Subdomain string
 	Domain    string
 	TLD       string
+
+	// rawFQDN and transitional carry what's needed to lazily compute
+	// Unicode()/ASCII() on first access, so a domain whose IDNA form can't
+	// round-trip (a garbage "xn--" label, say) doesn't fail extraction for
+	// callers who never asked for the converted form.
+	rawFQDN      string
+	transitional bool
+
+	unicodeComputed bool
+	unicodeFQDN     string
+	asciiComputed   bool
+	asciiFQDN       string
 }
 
+// defaultExtractor backs the package-level Extract/ExtractStrict/Update
+// functions, using the public suffix list bundled with gotldextract.
+var defaultExtractor = NewExtractor()
+
 // Extract parses a domain/URL and extracts its parts
 func Extract(domain string) (*Result, error) {
+	return defaultExtractor.Extract(domain)
+}
+
+// ExtractStrict parses a domain/URL like Extract, but first validates it
+// against RFC 1035/5890 FQDN syntax, returning a *LabelError or
+// ErrTotalTooLong if the domain is malformed.
+func ExtractStrict(domain string) (*Result, error) {
+	return defaultExtractor.ExtractStrict(domain)
+}
+
+// ExtractWithOptions parses a domain/URL and extracts its parts, applying
+// the given IDNA normalization before the suffix/domain/subdomain split.
+func ExtractWithOptions(domain string, opts ExtractOptions) (*Result, error) {
+	return defaultExtractor.ExtractWithOptions(domain, opts)
+}
+
+// ExtractWithOptions parses a domain/URL and extracts its parts using e's
+// public suffix list, applying the given IDNA normalization before the
+// suffix/domain/subdomain split.
+func (e *Extractor) ExtractWithOptions(domain string, opts ExtractOptions) (*Result, error) {
 	// Clean the domain (remove protocol if present)
 	domain = cleanDomain(domain)
-	
-	// Get the public suffix (TLD)
-	suffix, icann := publicsuffix.PublicSuffix(domain)
-	if !icann {
-		// If not an ICANN suffix, treat as private
-		suffix, _ = publicsuffix.PublicSuffix(domain)
+
+	// Use a lenient profile: it performs Punycode encoding/decoding only
+	// (no UTS-46 mapping; case was already folded by cleanDomain), and
+	// doesn't reject labels that are unusual but legal in practice
+	// (leading underscores, etc.). Strict RFC syntax checking, when
+	// requested, is handled separately by validateFQDN.
+	profile := idna.New(idna.Transitional(opts.Transitional))
+
+	// Only run the conversion the requested mode (or strict validation)
+	// actually needs for the split. A domain with a malformed "xn--" label
+	// that IDNA can't round-trip should still split the way it always has
+	// when nobody asked for it to be converted; toASCII/toUnicode below
+	// fall back to the raw domain on error rather than failing the call.
+	needASCII := opts.IDNAMode == IDNAToASCII || (opts.Strict && opts.IDNAMode != IDNAPreserve)
+	needUnicode := opts.IDNAMode == IDNAToUnicode
+
+	asciiFQDN := domain
+	if needASCII {
+		asciiFQDN = toASCII(profile, domain)
+	}
+	unicodeFQDN := domain
+	if needUnicode {
+		unicodeFQDN = toUnicode(profile, domain)
+	}
+
+	working := domain
+	switch opts.IDNAMode {
+	case IDNAToASCII:
+		working = asciiFQDN
+	case IDNAToUnicode:
+		working = unicodeFQDN
 	}
-	
+
+	if opts.Strict {
+		// Non-ASCII labels are only valid once IDNA has encoded them to
+		// their LDH-safe Punycode form.
+		validationTarget := domain
+		if opts.IDNAMode != IDNAPreserve {
+			validationTarget = asciiFQDN
+		}
+		if err := validateFQDN(validationTarget); err != nil {
+			return nil, err
+		}
+	}
+
+	// Get the public suffix (TLD)
+	suffix, _ := e.publicSuffix(working, opts.Suffixes)
+
 	// If the whole domain is just the suffix, it's not a valid domain
-	if domain == suffix {
+	if working == suffix {
 		return &Result{
-			Subdomain: "",
-			Domain:    "",
-			TLD:       suffix,
+			Subdomain:    "",
+			Domain:       "",
+			TLD:          suffix,
+			rawFQDN:      domain,
+			transitional: opts.Transitional,
 		}, nil
 	}
-	
+
 	// Remove the suffix to get the rest
-	domainWithoutSuffix := strings.TrimSuffix(domain, "."+suffix)
-	
+	domainWithoutSuffix := strings.TrimSuffix(working, "."+suffix)
+
 	// Split by dots
 	parts := strings.Split(domainWithoutSuffix, ".")
-	
+
 	if len(parts) == 0 {
 		return &Result{
-			Subdomain: "",
-			Domain:    "",
-			TLD:       suffix,
+			Subdomain:    "",
+			Domain:       "",
+			TLD:          suffix,
+			rawFQDN:      domain,
+			transitional: opts.Transitional,
 		}, nil
 	}
-	
+
 	// The last part is the domain name
 	domainName := parts[len(parts)-1]
-	
+
 	// Everything before is subdomain
 	subdomain := ""
 	if len(parts) > 1 {
 		subdomain = strings.Join(parts[:len(parts)-1], ".")
 	}
-	
+
 	return &Result{
-		Subdomain: subdomain,
-		Domain:    domainName,
-		TLD:       suffix,
+		Subdomain:    subdomain,
+		Domain:       domainName,
+		TLD:          suffix,
+		rawFQDN:      domain,
+		transitional: opts.Transitional,
 	}, nil
 }
 
@@ -70,11 +189,10 @@ func ExtractFromURL(url string) (*Result, error) {
 	return Extract(url)
 }
 
-// Update updates the public suffix list
-// Note: The golang.org/x/net/publicsuffix package uses an embedded list
-// that is updated when the package itself is updated.
+// Update refreshes the package-level public suffix list (used by Extract,
+// ExtractStrict, and ExtractWithOptions) from DefaultSuffixListURL.
 func Update() error {
-	return fmt.Errorf("update not supported: the public suffix list is embedded in the golang.org/x/net/publicsuffix package")
+	return defaultExtractor.Update()
 }
 
 // cleanDomain removes protocol and path from a URL to get just the domain
@@ -86,20 +204,20 @@ func cleanDomain(domain string) string {
 			domain = parts[1]
 		}
 	}
-	
+
 	// Remove path
 	if idx := strings.Index(domain, "/"); idx != -1 {
 		domain = domain[:idx]
 	}
-	
+
 	// Remove port
 	if idx := strings.Index(domain, ":"); idx != -1 {
 		domain = domain[:idx]
 	}
-	
+
 	// Remove trailing dot
 	domain = strings.TrimSuffix(domain, ".")
-	
+
 	return strings.ToLower(domain)
 }
 
@@ -124,4 +242,60 @@ func (r *Result) FQDN() string {
 		parts = append(parts, r.TLD)
 	}
 	return strings.Join(parts, ".")
-}
\ No newline at end of file
+}
+
+// NumLabels returns the number of dot-separated labels in the extracted
+// FQDN (subdomain, domain, and TLD combined).
+func (r *Result) NumLabels() int {
+	fqdn := r.FQDN()
+	if fqdn == "" {
+		return 0
+	}
+	return strings.Count(fqdn, ".") + 1
+}
+
+// Unicode returns the fully qualified domain name decoded to its Unicode
+// form, regardless of the encoding the input was given in. The conversion
+// is performed lazily on first call and cached; a domain that can't be
+// decoded (a malformed "xn--" label, say) falls back to the form Extract
+// was given rather than panicking or returning an error nobody asked for.
+func (r *Result) Unicode() string {
+	if !r.unicodeComputed {
+		profile := idna.New(idna.Transitional(r.transitional))
+		r.unicodeFQDN = toUnicode(profile, r.rawFQDN)
+		r.unicodeComputed = true
+	}
+	return r.unicodeFQDN
+}
+
+// ASCII returns the fully qualified domain name normalized to its ASCII
+// (Punycode, "xn--") form, regardless of the encoding the input was given
+// in. The conversion is performed lazily on first call and cached; a
+// domain that can't be encoded falls back to the form Extract was given
+// rather than panicking or returning an error nobody asked for.
+func (r *Result) ASCII() string {
+	if !r.asciiComputed {
+		profile := idna.New(idna.Transitional(r.transitional))
+		r.asciiFQDN = toASCII(profile, r.rawFQDN)
+		r.asciiComputed = true
+	}
+	return r.asciiFQDN
+}
+
+// toASCII converts fqdn to its Punycode form using profile, falling back to
+// fqdn unchanged if the conversion fails.
+func toASCII(profile *idna.Profile, fqdn string) string {
+	if converted, err := profile.ToASCII(fqdn); err == nil {
+		return converted
+	}
+	return fqdn
+}
+
+// toUnicode converts fqdn to its Unicode form using profile, falling back
+// to fqdn unchanged if the conversion fails.
+func toUnicode(profile *idna.Profile, fqdn string) string {
+	if converted, err := profile.ToUnicode(fqdn); err == nil {
+		return converted
+	}
+	return fqdn
+}
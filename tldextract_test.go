@@ -1,6 +1,8 @@
 package gotldextract
 
 import (
+	"errors"
+	"strings"
 	"testing"
 )
 
@@ -219,6 +221,182 @@ func TestResultString(t *testing.T) {
 	}
 }
 
+func TestExtractWithOptionsIDNA(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		mode      IDNAMode
+		subdomain string
+		domain    string
+		tld       string
+	}{
+		{
+			name:      "unicode input preserved by default",
+			input:     "例え.jp",
+			mode:      IDNAPreserve,
+			subdomain: "",
+			domain:    "例え",
+			tld:       "jp",
+		},
+		{
+			name:      "unicode input normalized to ASCII",
+			input:     "例え.jp",
+			mode:      IDNAToASCII,
+			subdomain: "",
+			domain:    "xn--r8jz45g",
+			tld:       "jp",
+		},
+		{
+			name:      "punycode input decoded to Unicode",
+			input:     "xn--r8jz45g.xn--zckzah",
+			mode:      IDNAToUnicode,
+			subdomain: "",
+			domain:    "例え",
+			tld:       "テスト",
+		},
+		{
+			name:      "punycode input preserved by default",
+			input:     "xn--r8jz45g.xn--zckzah",
+			mode:      IDNAPreserve,
+			subdomain: "",
+			domain:    "xn--r8jz45g",
+			tld:       "xn--zckzah",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ExtractWithOptions(tt.input, ExtractOptions{IDNAMode: tt.mode})
+			if err != nil {
+				t.Fatalf("ExtractWithOptions() error = %v", err)
+			}
+
+			if result.Subdomain != tt.subdomain {
+				t.Errorf("Subdomain = %v, want %v", result.Subdomain, tt.subdomain)
+			}
+			if result.Domain != tt.domain {
+				t.Errorf("Domain = %v, want %v", result.Domain, tt.domain)
+			}
+			if result.TLD != tt.tld {
+				t.Errorf("TLD = %v, want %v", result.TLD, tt.tld)
+			}
+		})
+	}
+}
+
+func TestResultUnicodeAndASCII(t *testing.T) {
+	result, err := Extract("例え.jp")
+	if err != nil {
+		t.Fatalf("Extract() error = %v", err)
+	}
+
+	if got, want := result.Unicode(), "例え.jp"; got != want {
+		t.Errorf("Unicode() = %v, want %v", got, want)
+	}
+	if got, want := result.ASCII(), "xn--r8jz45g.jp"; got != want {
+		t.Errorf("ASCII() = %v, want %v", got, want)
+	}
+}
+
+func TestExtractStrict(t *testing.T) {
+	tests := []struct {
+		name       string
+		input      string
+		wantErr    error
+		labelIndex int
+	}{
+		{
+			name:  "valid domain",
+			input: "www.example.com",
+		},
+		{
+			name:       "empty label",
+			input:      "foo..com",
+			wantErr:    ErrEmptyLabel,
+			labelIndex: 1,
+		},
+		{
+			name:       "label too long",
+			input:      strings.Repeat("a", 64) + ".com",
+			wantErr:    ErrLabelTooLong,
+			labelIndex: 0,
+		},
+		{
+			name:       "leading hyphen",
+			input:      "-foo.com",
+			wantErr:    ErrInvalidChar,
+			labelIndex: 0,
+		},
+		{
+			name:       "trailing hyphen",
+			input:      "foo-.com",
+			wantErr:    ErrInvalidChar,
+			labelIndex: 0,
+		},
+		{
+			name:       "invalid character",
+			input:      "foo_bar.com",
+			wantErr:    ErrInvalidChar,
+			labelIndex: 0,
+		},
+		{
+			name:    "total too long",
+			input:   strings.Repeat("a", 63) + "." + strings.Repeat("b", 63) + "." + strings.Repeat("c", 63) + "." + strings.Repeat("d", 63) + ".com",
+			wantErr: ErrTotalTooLong,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ExtractStrict(tt.input)
+			if tt.wantErr == nil {
+				if err != nil {
+					t.Fatalf("ExtractStrict() error = %v, want nil", err)
+				}
+				return
+			}
+
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ExtractStrict() error = %v, want %v", err, tt.wantErr)
+			}
+
+			var labelErr *LabelError
+			if errors.As(err, &labelErr) {
+				if labelErr.LabelIndex != tt.labelIndex {
+					t.Errorf("LabelIndex = %v, want %v", labelErr.LabelIndex, tt.labelIndex)
+				}
+			}
+		})
+	}
+}
+
+func TestResultNumLabels(t *testing.T) {
+	result, err := Extract("api.v2.staging.example.com")
+	if err != nil {
+		t.Fatalf("Extract() error = %v", err)
+	}
+	if got, want := result.NumLabels(), 5; got != want {
+		t.Errorf("NumLabels() = %v, want %v", got, want)
+	}
+}
+
+func TestToFQDN(t *testing.T) {
+	fqdn, err := ToFQDN("www.example.com")
+	if err != nil {
+		t.Fatalf("ToFQDN() error = %v", err)
+	}
+	if got, want := fqdn.WithTrailingDot(), FQDN("www.example.com."); got != want {
+		t.Errorf("WithTrailingDot() = %v, want %v", got, want)
+	}
+	if got, want := fqdn.WithTrailingDot().WithoutTrailingDot(), fqdn; got != want {
+		t.Errorf("WithoutTrailingDot() = %v, want %v", got, want)
+	}
+
+	if _, err := ToFQDN("foo..com"); !errors.Is(err, ErrEmptyLabel) {
+		t.Errorf("ToFQDN() error = %v, want %v", err, ErrEmptyLabel)
+	}
+}
+
 func TestCleanDomain(t *testing.T) {
 	tests := []struct {
 		name  string